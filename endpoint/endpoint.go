@@ -0,0 +1,132 @@
+// Package endpoint centralizes HTTP error handling: handlers return an error
+// instead of writing their own error response, and Handle renders it as a
+// consistent JSON body while logging it at a severity derived from its
+// status code.
+package endpoint
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/Manav7603/Logger/logger"
+	"github.com/Manav7603/Logger/middleware"
+)
+
+// Endpoint is an HTTP handler that reports failure by returning an error
+// instead of writing its own error response.
+type Endpoint func(w http.ResponseWriter, r *http.Request) error
+
+// HandlerError is the error type Endpoints should return to control the
+// status code and body of the rendered error response. Any other error
+// returned from an Endpoint is treated as an unexpected 500. Construct it
+// with NewHandlerError rather than the struct literal so Stack is captured
+// at the point the error occurred, not later when WriteError logs it.
+type HandlerError struct {
+	Status  int
+	Code    string
+	Message string
+	Err     error
+	Stack   []string
+}
+
+// NewHandlerError builds a HandlerError, capturing the caller's stack
+// immediately so WriteError's log entry points at the code that produced the
+// error rather than Handle's already-unwound call site.
+func NewHandlerError(status int, code, message string, err error) *HandlerError {
+	return &HandlerError{
+		Status:  status,
+		Code:    code,
+		Message: message,
+		Err:     err,
+		Stack:   middleware.CaptureStack(3),
+	}
+}
+
+func (e *HandlerError) Error() string {
+	if e.Err != nil {
+		return e.Message + ": " + e.Err.Error()
+	}
+	return e.Message
+}
+
+func (e *HandlerError) Unwrap() error { return e.Err }
+
+// ErrResponse is the JSON body written for a failed request.
+type ErrResponse struct {
+	Status    int    `json:"status"`
+	Err       string `json:"error"`
+	Message   string `json:"message"`
+	Details   string `json:"details,omitempty"`
+	Code      string `json:"code,omitempty"`
+	RequestID string `json:"request_id,omitempty"`
+}
+
+// suppressedStatuses lists status codes WriteError renders but doesn't log,
+// since they represent routine client behavior rather than a server fault.
+var suppressedStatuses = map[int]bool{
+	http.StatusNotFound: true,
+}
+
+// SuppressLogging adds status codes to the logging allow-list; WriteError
+// still renders the JSON response for these but skips the log entry.
+func SuppressLogging(statuses ...int) {
+	for _, s := range statuses {
+		suppressedStatuses[s] = true
+	}
+}
+
+// Handle adapts an Endpoint to an http.HandlerFunc, rendering any returned
+// error via WriteError.
+func Handle(e Endpoint) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if err := e(w, r); err != nil {
+			herr, ok := err.(*HandlerError)
+			if !ok {
+				herr = NewHandlerError(http.StatusInternalServerError, "INTERNAL", "internal server error", err)
+			}
+			WriteError(w, r, herr)
+		}
+	}
+}
+
+// WriteError logs herr (unless its status is on the suppressed list) and
+// writes it to w as an ErrResponse.
+func WriteError(w http.ResponseWriter, r *http.Request, herr *HandlerError) {
+	status := herr.Status
+	if status == 0 {
+		status = http.StatusInternalServerError
+	}
+
+	if !suppressedStatuses[status] {
+		log := middleware.FromContext(r.Context())
+		fields := []logger.Field{
+			logger.Int("status", status),
+			logger.String("code", herr.Code),
+			logger.Any("stack", herr.Stack),
+		}
+		if herr.Err != nil {
+			fields = append(fields, logger.Err(herr.Err))
+		}
+		if status >= 500 {
+			log.Error(herr.Message, fields...)
+		} else {
+			log.Warn(herr.Message, fields...)
+		}
+	}
+
+	details := ""
+	if herr.Err != nil {
+		details = herr.Err.Error()
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(ErrResponse{
+		Status:    status,
+		Err:       http.StatusText(status),
+		Message:   herr.Message,
+		Details:   details,
+		Code:      herr.Code,
+		RequestID: middleware.RequestIDFromContext(r.Context()),
+	})
+}
@@ -0,0 +1,44 @@
+package main
+
+import (
+	"fmt"
+	"net/http"
+	"sync/atomic"
+)
+
+// readiness tracks whether the server should still receive traffic. It
+// starts ready and flips to not-ready once graceful shutdown begins, so
+// readyz starts failing before in-flight requests finish draining and the
+// load balancer stops sending new ones.
+type readiness struct {
+	ready atomic.Bool
+}
+
+func newReadiness() *readiness {
+	r := &readiness{}
+	r.ready.Store(true)
+	return r
+}
+
+func (r *readiness) setNotReady() {
+	r.ready.Store(false)
+}
+
+// healthz is a liveness probe: it reports OK as long as the process is
+// running and able to handle HTTP requests.
+func (r *readiness) healthz(w http.ResponseWriter, req *http.Request) {
+	w.WriteHeader(http.StatusOK)
+	fmt.Fprint(w, "ok\n")
+}
+
+// readyz is a readiness probe: it reports OK until shutdown has been
+// requested, then 503 so the load balancer stops routing new traffic here.
+func (r *readiness) readyz(w http.ResponseWriter, req *http.Request) {
+	if !r.ready.Load() {
+		w.WriteHeader(http.StatusServiceUnavailable)
+		fmt.Fprint(w, "shutting down\n")
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+	fmt.Fprint(w, "ok\n")
+}
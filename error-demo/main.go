@@ -1,34 +1,34 @@
 package main
 
 import (
-    "encoding/json"
-    "fmt"
-    "log"
-    "net/http"
-    "os"
-    "time"
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/Manav7603/Logger/endpoint"
+	"github.com/Manav7603/Logger/logger"
+	"github.com/Manav7603/Logger/logger/cloudlogger"
+	"github.com/Manav7603/Logger/middleware"
 )
 
-//
-// ────────────────────────────────────────────────────────────
-//   NOTE ON LOGGING:
-//   • log.Println(...) → writes to stderr (Cloud Logging treats as ERROR).
-//   • fmt.Println(...) → writes to stdout (Cloud Logging treats as INFO).
-//   To produce a “WARNING,” write a single‐line JSON with "severity":"WARNING".
-// ────────────────────────────────────────────────────────────
-//
+// defaultShutdownTimeout fits inside Cloud Run's 30s SIGTERM grace period.
+const defaultShutdownTimeout = 25 * time.Second
 
 // homeHandler serves an HTML page with buttons to trigger different log severities.
 func homeHandler(w http.ResponseWriter, r *http.Request) {
-    // 1) Log an INFO to stdout so Cloud Logging marks it severity=INFO.
-    fmt.Println("INFO:", "home page visited at", time.Now().Format(time.RFC3339))
-
-    // 2) Serve a simple HTML page with four buttons:
-    //    • /trigger-error
-    //    • /trigger-panic
-    //    • /trigger-warning
-    //    • /trigger-custom
-    fmt.Fprint(w, `
+	middleware.FromContext(r.Context()).Info("home page visited", logger.String("path", r.URL.Path))
+
+	// Serve a simple HTML page with four buttons:
+	//    • /trigger-error
+	//    • /trigger-panic
+	//    • /trigger-warning
+	//    • /trigger-custom
+	fmt.Fprint(w, `
   <!DOCTYPE html>
   <html>
     <head><title>Error Demo</title></head>
@@ -46,69 +46,106 @@ func homeHandler(w http.ResponseWriter, r *http.Request) {
   </html>`)
 }
 
-// errorHandler logs a basic ERROR and returns HTTP 500.
-func errorHandler(w http.ResponseWriter, r *http.Request) {
-    log.Println("ERROR:", "generic error triggered by /trigger-error at", time.Now().Format(time.RFC3339))
-    w.WriteHeader(http.StatusInternalServerError)
-    fmt.Fprint(w, "500 Internal Server Error: generic error was triggered.\n")
+// errorHandler returns a generic 500 HandlerError.
+func errorHandler(w http.ResponseWriter, r *http.Request) error {
+	return endpoint.NewHandlerError(http.StatusInternalServerError, "GENERIC",
+		"generic error was triggered", errors.New("triggered by /trigger-error"))
 }
 
 // panicHandler logs an ERROR then panics (simulating a crash).
 func panicHandler(w http.ResponseWriter, r *http.Request) {
-    log.Println("ERROR:", "about to panic (triggered by /trigger-panic) at", time.Now().Format(time.RFC3339))
-    panic("✨ intentional panic: simulated crash for demo ✨")
+	middleware.FromContext(r.Context()).Error("about to panic", logger.String("path", r.URL.Path))
+	panic("✨ intentional panic: simulated crash for demo ✨")
 }
 
-// warningHandler emits a JSON “WARNING” log to stdout.
+// warningHandler emits a structured WARNING log.
 func warningHandler(w http.ResponseWriter, r *http.Request) {
-    rec := map[string]interface{}{
-        "severity": "WARNING",
-        "message":  "This is a WARNING log triggered by /trigger-warning",
-        "time":     time.Now().Format(time.RFC3339),
-    }
-    raw, _ := json.Marshal(rec)
-    fmt.Println(string(raw)) // stdout → Cloud Logging picks up severity=WARNING
-
-    w.WriteHeader(http.StatusOK)
-    fmt.Fprint(w, "200 OK: a WARNING log was emitted.\n")
+	middleware.FromContext(r.Context()).Warn("this is a WARNING log triggered by /trigger-warning", logger.String("path", r.URL.Path))
+
+	w.WriteHeader(http.StatusOK)
+	fmt.Fprint(w, "200 OK: a WARNING log was emitted.\n")
 }
 
-// customHandler emits a structured JSON “database error” to stderr.
-func customHandler(w http.ResponseWriter, r *http.Request) {
-    errRec := map[string]interface{}{
-        "severity":     "ERROR",
-        "errorType":    "DatabaseConnectionError",
-        "description":  "Unable to connect to DB host 'db-primary:5432'",
-        "retryable":    false,
-        "timestamp":    time.Now().Format(time.RFC3339),
-    }
-    raw, _ := json.Marshal(errRec)
-    fmt.Fprintln(os.Stderr, string(raw)) // stderr → severity=ERROR
-
-    w.WriteHeader(http.StatusInternalServerError)
-    fmt.Fprint(w, "500 Internal Server Error: database connection error simulated.\n")
+// customHandler returns a HandlerError simulating a database connection failure.
+func customHandler(w http.ResponseWriter, r *http.Request) error {
+	return endpoint.NewHandlerError(http.StatusInternalServerError, "DB_CONN",
+		"unable to connect to DB host 'db-primary:5432'", errors.New("database connection error simulated"))
 }
 
 func main() {
-    // Log startup (INFO → stdout)
-    fmt.Println("INFO:", "starting Error Demo server on port", getPort(), "at", time.Now().Format(time.RFC3339))
-
-    // Register HTTP handlers
-    http.HandleFunc("/", homeHandler)
-    http.HandleFunc("/trigger-error", errorHandler)
-    http.HandleFunc("/trigger-panic", panicHandler)
-    http.HandleFunc("/trigger-warning", warningHandler)
-    http.HandleFunc("/trigger-custom", customHandler)
-
-    // Listen on the provided PORT (Cloud Run sets this); default to 8080 if unset.
-    port := getPort()
-    log.Fatal(http.ListenAndServe(":"+port, nil))
+	logger.SetLogger(cloudlogger.NewStdout())
+
+	// Log startup (INFO)
+	logger.Info("starting Error Demo server", logger.String("port", getPort()))
+
+	ready := newReadiness()
+
+	// Register HTTP handlers behind the panic-recovery middleware so a crash
+	// in any one of them (see /trigger-panic) can't take down the server.
+	router := middleware.NewRouter()
+	router.Use(middleware.Trace, middleware.Recoverer)
+	// /trigger-error and /trigger-panic get an extra per-IP rate limit on top
+	// of the global Trace/Recoverer chain, so hammering the demo's crash
+	// buttons can't be used to spam the logs.
+	limited := middleware.RateLimit(5, 10, nil)
+
+	router.HandleFunc("/", homeHandler)
+	router.Handle("/trigger-error", limited(endpoint.Handle(errorHandler)))
+	router.Handle("/trigger-panic", limited(http.HandlerFunc(panicHandler)))
+	router.HandleFunc("/trigger-warning", warningHandler)
+	router.HandleFunc("/trigger-custom", endpoint.Handle(customHandler))
+	router.HandleFunc("/healthz", ready.healthz)
+	router.HandleFunc("/readyz", ready.readyz)
+
+	srv := &http.Server{
+		Addr:    ":" + getPort(),
+		Handler: router,
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	go func() {
+		logger.Info("server listening", logger.String("addr", srv.Addr))
+		if err := srv.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+			logger.Fatal("server exited unexpectedly", logger.Err(err))
+		}
+	}()
+
+	<-ctx.Done()
+	stop()
+	logger.Info("shutdown requested")
+	ready.setNotReady()
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), shutdownTimeout())
+	defer cancel()
+
+	start := time.Now()
+	if err := srv.Shutdown(shutdownCtx); err != nil {
+		logger.Error("graceful shutdown failed", logger.Err(err))
+	}
+	logger.Info("drained", logger.String("duration", time.Since(start).String()))
 }
 
 // getPort returns the PORT env var or "8080" if not set.
 func getPort() string {
-    if p := os.Getenv("PORT"); p != "" {
-        return p
-    }
-    return "8080"
+	if p := os.Getenv("PORT"); p != "" {
+		return p
+	}
+	return "8080"
+}
+
+// shutdownTimeout returns the SHUTDOWN_TIMEOUT env var parsed as a duration,
+// or defaultShutdownTimeout if unset or invalid.
+func shutdownTimeout() time.Duration {
+	v := os.Getenv("SHUTDOWN_TIMEOUT")
+	if v == "" {
+		return defaultShutdownTimeout
+	}
+	d, err := time.ParseDuration(v)
+	if err != nil {
+		logger.Warn("invalid SHUTDOWN_TIMEOUT, using default", logger.String("value", v), logger.Err(err))
+		return defaultShutdownTimeout
+	}
+	return d
 }
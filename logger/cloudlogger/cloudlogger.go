@@ -0,0 +1,59 @@
+// Package cloudlogger implements logger.Logger by writing one JSON line per
+// entry to stdout in the structured format Cloud Logging's stdout/stderr
+// agent expects on Cloud Run and GKE, so severities are reported correctly
+// without the fmt.Println-vs-log.Println stdout/stderr trick.
+package cloudlogger
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/Manav7603/Logger/logger"
+)
+
+// Logger is a logger.Logger backend that emits structured JSON log entries.
+type Logger struct {
+	w io.Writer
+}
+
+// New returns a Logger that writes JSON entries to w.
+func New(w io.Writer) *Logger {
+	return &Logger{w: w}
+}
+
+// NewStdout returns a Logger that writes JSON entries to os.Stdout, which is
+// the stream Cloud Run and GKE's logging agents scrape.
+func NewStdout() *Logger {
+	return New(os.Stdout)
+}
+
+func (c *Logger) emit(severity, msg string, fields []logger.Field) {
+	rec := make(map[string]interface{}, len(fields)+3)
+	rec["severity"] = severity
+	rec["time"] = time.Now().Format(time.RFC3339Nano)
+	rec["message"] = msg
+	for _, f := range fields {
+		rec[f.Key] = f.Value
+	}
+	raw, err := json.Marshal(rec)
+	if err != nil {
+		fmt.Fprintf(c.w, `{"severity":"ERROR","message":"cloudlogger: failed to marshal log entry: %s"}`+"\n", err)
+		return
+	}
+	fmt.Fprintln(c.w, string(raw))
+}
+
+func (c *Logger) Debug(msg string, fields ...logger.Field)    { c.emit("DEBUG", msg, fields) }
+func (c *Logger) Info(msg string, fields ...logger.Field)     { c.emit("INFO", msg, fields) }
+func (c *Logger) Warn(msg string, fields ...logger.Field)     { c.emit("WARNING", msg, fields) }
+func (c *Logger) Error(msg string, fields ...logger.Field)    { c.emit("ERROR", msg, fields) }
+func (c *Logger) Critical(msg string, fields ...logger.Field) { c.emit("CRITICAL", msg, fields) }
+
+// Fatal emits a CRITICAL entry and then exits the process with status 1.
+func (c *Logger) Fatal(msg string, fields ...logger.Field) {
+	c.emit("CRITICAL", msg, fields)
+	os.Exit(1)
+}
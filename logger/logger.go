@@ -0,0 +1,85 @@
+// Package logger defines a small, pluggable leveled logging interface.
+//
+// Callers log against the package-level functions (Debug, Info, Warn, Error,
+// Fatal), which delegate to a swappable Logger singleton — LOGHANDLER. This
+// mirrors WebGo's LOGHANDLER pattern: set it once at startup (e.g. to a
+// cloudlogger.Logger in production) and every call site gets the new
+// behavior for free.
+package logger
+
+import "os"
+
+// Field is a single structured key/value pair attached to a log entry.
+type Field struct {
+	Key   string
+	Value interface{}
+}
+
+// String builds a string-valued Field.
+func String(key, value string) Field {
+	return Field{Key: key, Value: value}
+}
+
+// Int builds an int-valued Field.
+func Int(key string, value int) Field {
+	return Field{Key: key, Value: value}
+}
+
+// Bool builds a bool-valued Field.
+func Bool(key string, value bool) Field {
+	return Field{Key: key, Value: value}
+}
+
+// Any builds a Field from an arbitrary value.
+func Any(key string, value interface{}) Field {
+	return Field{Key: key, Value: value}
+}
+
+// Err builds a Field carrying an error's message under the key "error".
+func Err(err error) Field {
+	if err == nil {
+		return Field{Key: "error", Value: nil}
+	}
+	return Field{Key: "error", Value: err.Error()}
+}
+
+// Logger is the leveled logging interface implemented by every backend.
+// Critical is for severe-but-recovered conditions (e.g. a recovered panic)
+// that warrant paging attention without exiting the process; Fatal must log
+// the entry and then terminate the process via os.Exit(1).
+type Logger interface {
+	Debug(msg string, fields ...Field)
+	Info(msg string, fields ...Field)
+	Warn(msg string, fields ...Field)
+	Error(msg string, fields ...Field)
+	Critical(msg string, fields ...Field)
+	Fatal(msg string, fields ...Field)
+}
+
+// LOGHANDLER is the package-level singleton used by the Debug/Info/Warn/
+// Error/Fatal package functions. Replace it at startup with SetLogger to
+// switch backends (e.g. to a cloudlogger.Logger on Cloud Run).
+var LOGHANDLER Logger = NewTextLogger(os.Stderr)
+
+// SetLogger replaces the package-level singleton.
+func SetLogger(l Logger) {
+	LOGHANDLER = l
+}
+
+// Debug logs via the current LOGHANDLER.
+func Debug(msg string, fields ...Field) { LOGHANDLER.Debug(msg, fields...) }
+
+// Info logs via the current LOGHANDLER.
+func Info(msg string, fields ...Field) { LOGHANDLER.Info(msg, fields...) }
+
+// Warn logs via the current LOGHANDLER.
+func Warn(msg string, fields ...Field) { LOGHANDLER.Warn(msg, fields...) }
+
+// Error logs via the current LOGHANDLER.
+func Error(msg string, fields ...Field) { LOGHANDLER.Error(msg, fields...) }
+
+// Critical logs via the current LOGHANDLER.
+func Critical(msg string, fields ...Field) { LOGHANDLER.Critical(msg, fields...) }
+
+// Fatal logs via the current LOGHANDLER and then exits the process.
+func Fatal(msg string, fields ...Field) { LOGHANDLER.Fatal(msg, fields...) }
@@ -0,0 +1,43 @@
+package logger
+
+import (
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"strings"
+)
+
+// TextLogger is a plain stdlib-backed Logger that writes human-readable
+// lines of the form "LEVEL: msg key=value key=value" via a *log.Logger.
+type TextLogger struct {
+	l *log.Logger
+}
+
+// NewTextLogger returns a TextLogger writing to w.
+func NewTextLogger(w io.Writer) *TextLogger {
+	return &TextLogger{l: log.New(w, "", log.LstdFlags)}
+}
+
+func (t *TextLogger) log(level, msg string, fields []Field) {
+	var b strings.Builder
+	b.WriteString(level)
+	b.WriteString(": ")
+	b.WriteString(msg)
+	for _, f := range fields {
+		fmt.Fprintf(&b, " %s=%v", f.Key, f.Value)
+	}
+	t.l.Print(b.String())
+}
+
+func (t *TextLogger) Debug(msg string, fields ...Field)    { t.log("DEBUG", msg, fields) }
+func (t *TextLogger) Info(msg string, fields ...Field)     { t.log("INFO", msg, fields) }
+func (t *TextLogger) Warn(msg string, fields ...Field)     { t.log("WARNING", msg, fields) }
+func (t *TextLogger) Error(msg string, fields ...Field)    { t.log("ERROR", msg, fields) }
+func (t *TextLogger) Critical(msg string, fields ...Field) { t.log("CRITICAL", msg, fields) }
+
+// Fatal logs the entry and then exits the process with status 1.
+func (t *TextLogger) Fatal(msg string, fields ...Field) {
+	t.log("FATAL", msg, fields)
+	os.Exit(1)
+}
@@ -0,0 +1,34 @@
+package logger
+
+// fieldLogger decorates a Logger, prepending a fixed set of fields to every
+// call. It backs WithFields, which the trace middleware uses to bind a
+// request's request ID and Cloud Logging trace fields to every log line
+// emitted while handling that request.
+type fieldLogger struct {
+	base   Logger
+	fields []Field
+}
+
+// WithFields returns a Logger that logs through base with fields prepended
+// to every call's fields.
+func WithFields(base Logger, fields ...Field) Logger {
+	bound := make([]Field, len(fields))
+	copy(bound, fields)
+	return &fieldLogger{base: base, fields: bound}
+}
+
+func (f *fieldLogger) merge(fields []Field) []Field {
+	out := make([]Field, 0, len(f.fields)+len(fields))
+	out = append(out, f.fields...)
+	out = append(out, fields...)
+	return out
+}
+
+func (f *fieldLogger) Debug(msg string, fields ...Field) { f.base.Debug(msg, f.merge(fields)...) }
+func (f *fieldLogger) Info(msg string, fields ...Field)  { f.base.Info(msg, f.merge(fields)...) }
+func (f *fieldLogger) Warn(msg string, fields ...Field)  { f.base.Warn(msg, f.merge(fields)...) }
+func (f *fieldLogger) Error(msg string, fields ...Field) { f.base.Error(msg, f.merge(fields)...) }
+func (f *fieldLogger) Critical(msg string, fields ...Field) {
+	f.base.Critical(msg, f.merge(fields)...)
+}
+func (f *fieldLogger) Fatal(msg string, fields ...Field) { f.base.Fatal(msg, f.merge(fields)...) }
@@ -0,0 +1,54 @@
+package middleware
+
+import (
+	"io"
+	"net/http"
+	"os"
+	"sync"
+	"time"
+)
+
+const metadataProjectIDURL = "http://metadata.google.internal/computeMetadata/v1/project/project-id"
+
+var (
+	projectIDOnce   sync.Once
+	cachedProjectID string
+)
+
+// projectID returns the current GCP project ID, read from GOOGLE_CLOUD_PROJECT
+// if set, otherwise fetched once from the GCE metadata server. It returns ""
+// outside of GCP/Cloud Run (e.g. running locally with no env var set).
+func projectID() string {
+	projectIDOnce.Do(func() {
+		if p := os.Getenv("GOOGLE_CLOUD_PROJECT"); p != "" {
+			cachedProjectID = p
+			return
+		}
+		cachedProjectID = fetchMetadataProjectID()
+	})
+	return cachedProjectID
+}
+
+func fetchMetadataProjectID() string {
+	req, err := http.NewRequest(http.MethodGet, metadataProjectIDURL, nil)
+	if err != nil {
+		return ""
+	}
+	req.Header.Set("Metadata-Flavor", "Google")
+
+	client := http.Client{Timeout: 500 * time.Millisecond}
+	resp, err := client.Do(req)
+	if err != nil {
+		return ""
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return ""
+	}
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return ""
+	}
+	return string(body)
+}
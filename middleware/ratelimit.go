@@ -0,0 +1,148 @@
+package middleware
+
+import (
+	"hash/fnv"
+	"net"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"golang.org/x/time/rate"
+
+	"github.com/Manav7603/Logger/logger"
+)
+
+const (
+	rateLimiterShards    = 16
+	defaultIdleBucketTTL = 10 * time.Minute
+)
+
+// bucket is one caller's token bucket plus the last time it was touched, so
+// the sweeper can evict buckets nobody has used in a while.
+type bucket struct {
+	limiter  *rate.Limiter
+	lastSeen time.Time
+}
+
+type rateLimiterShard struct {
+	mu      sync.Mutex
+	buckets map[string]*bucket
+}
+
+// RateLimiter maintains a sharded map of per-key token buckets, so one busy
+// key's mutex contention doesn't slow down requests keyed elsewhere.
+type RateLimiter struct {
+	shards  [rateLimiterShards]*rateLimiterShard
+	rps     rate.Limit
+	burst   int
+	idleTTL time.Duration
+}
+
+// newRateLimiter builds a RateLimiter and starts its background sweeper.
+// idleTTL is taken as a parameter (rather than set post-construction) so
+// callers — including tests — can fix it before the sweeper goroutine ever
+// reads it, avoiding a data race on the field.
+func newRateLimiter(rps float64, burst int, idleTTL time.Duration) *RateLimiter {
+	rl := &RateLimiter{
+		rps:     rate.Limit(rps),
+		burst:   burst,
+		idleTTL: idleTTL,
+	}
+	for i := range rl.shards {
+		rl.shards[i] = &rateLimiterShard{buckets: make(map[string]*bucket)}
+	}
+	go rl.sweep()
+	return rl
+}
+
+func (rl *RateLimiter) shardFor(key string) *rateLimiterShard {
+	h := fnv.New32a()
+	h.Write([]byte(key))
+	return rl.shards[h.Sum32()%rateLimiterShards]
+}
+
+func (rl *RateLimiter) allow(key string) bool {
+	s := rl.shardFor(key)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	b, ok := s.buckets[key]
+	if !ok {
+		b = &bucket{limiter: rate.NewLimiter(rl.rps, rl.burst)}
+		s.buckets[key] = b
+	}
+	b.lastSeen = time.Now()
+	return b.limiter.Allow()
+}
+
+// sweep periodically evicts buckets idle for longer than idleTTL so the map
+// doesn't grow unbounded with one-off callers.
+func (rl *RateLimiter) sweep() {
+	ticker := time.NewTicker(rl.idleTTL)
+	defer ticker.Stop()
+	for now := range ticker.C {
+		rl.evictIdle(now)
+	}
+}
+
+// evictIdle removes every bucket last touched before now-idleTTL.
+func (rl *RateLimiter) evictIdle(now time.Time) {
+	cutoff := now.Add(-rl.idleTTL)
+	for _, s := range rl.shards {
+		s.mu.Lock()
+		for key, b := range s.buckets {
+			if b.lastSeen.Before(cutoff) {
+				delete(s.buckets, key)
+			}
+		}
+		s.mu.Unlock()
+	}
+}
+
+// defaultRateLimitKey keys by the first hop of X-Forwarded-For, falling back
+// to RemoteAddr, so requests behind a load balancer are still rate-limited
+// per client rather than per proxy.
+func defaultRateLimitKey(r *http.Request) string {
+	if xff := r.Header.Get("X-Forwarded-For"); xff != "" {
+		if i := strings.IndexByte(xff, ','); i >= 0 {
+			return strings.TrimSpace(xff[:i])
+		}
+		return strings.TrimSpace(xff)
+	}
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+	return host
+}
+
+// RateLimit returns a middleware enforcing a token-bucket limit of rps
+// requests/sec with the given burst, keyed per request by keyFn (or
+// defaultRateLimitKey if keyFn is nil). Rejected requests get a 429 with
+// Retry-After set and a WARNING structured log.
+func RateLimit(rps, burst int, keyFn func(*http.Request) string) Middleware {
+	if keyFn == nil {
+		keyFn = defaultRateLimitKey
+	}
+	rl := newRateLimiter(float64(rps), burst, defaultIdleBucketTTL)
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			key := keyFn(r)
+			if !rl.allow(key) {
+				FromContext(r.Context()).Warn("rate limit exceeded",
+					logger.String("key", key),
+					logger.String("path", r.URL.Path),
+					logger.Int("limit", rps),
+					logger.Int("burst", burst),
+				)
+				w.Header().Set("Retry-After", "1")
+				w.WriteHeader(http.StatusTooManyRequests)
+				w.Write([]byte("429 Too Many Requests\n"))
+				return
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
@@ -0,0 +1,114 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestDefaultRateLimitKey(t *testing.T) {
+	cases := []struct {
+		name       string
+		xff        string
+		remoteAddr string
+		want       string
+	}{
+		{
+			name:       "single XFF hop",
+			xff:        "203.0.113.1",
+			remoteAddr: "10.0.0.1:1234",
+			want:       "203.0.113.1",
+		},
+		{
+			name:       "XFF takes the first of multiple hops",
+			xff:        "203.0.113.1, 10.0.0.2, 10.0.0.3",
+			remoteAddr: "10.0.0.1:1234",
+			want:       "203.0.113.1",
+		},
+		{
+			name:       "no XFF falls back to RemoteAddr host",
+			remoteAddr: "198.51.100.1:5678",
+			want:       "198.51.100.1",
+		},
+		{
+			name:       "no XFF and unparseable RemoteAddr falls back verbatim",
+			remoteAddr: "not-a-host-port",
+			want:       "not-a-host-port",
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			r := httptest.NewRequest(http.MethodGet, "/", nil)
+			r.RemoteAddr = c.remoteAddr
+			if c.xff != "" {
+				r.Header.Set("X-Forwarded-For", c.xff)
+			}
+			if got := defaultRateLimitKey(r); got != c.want {
+				t.Errorf("defaultRateLimitKey() = %q, want %q", got, c.want)
+			}
+		})
+	}
+}
+
+func TestRateLimiterShardForIsStable(t *testing.T) {
+	rl := newRateLimiter(1, 1, defaultIdleBucketTTL)
+	want := rl.shardFor("same-key")
+	for i := 0; i < 5; i++ {
+		if got := rl.shardFor("same-key"); got != want {
+			t.Fatalf("shardFor(%q) returned a different shard on call %d", "same-key", i)
+		}
+	}
+}
+
+func TestRateLimiterAllow(t *testing.T) {
+	rl := newRateLimiter(0, 2, defaultIdleBucketTTL) // rps=0: bucket only ever has its initial burst.
+
+	if !rl.allow("k") {
+		t.Fatal("first request should be allowed (within burst)")
+	}
+	if !rl.allow("k") {
+		t.Fatal("second request should be allowed (within burst)")
+	}
+	if rl.allow("k") {
+		t.Fatal("third request should be rejected once the burst is exhausted")
+	}
+
+	// A different key has its own bucket and isn't affected by k's usage.
+	if !rl.allow("other") {
+		t.Fatal("a different key should have its own, unused bucket")
+	}
+}
+
+func TestRateLimiterEvictIdle(t *testing.T) {
+	rl := newRateLimiter(1, 1, time.Minute)
+
+	rl.allow("stale")
+	rl.allow("fresh")
+
+	now := time.Now()
+	s := rl.shardFor("stale")
+	s.mu.Lock()
+	s.buckets["stale"].lastSeen = now.Add(-2 * time.Minute)
+	s.mu.Unlock()
+
+	rl.evictIdle(now)
+
+	if s2 := rl.shardFor("stale"); func() bool {
+		s2.mu.Lock()
+		defer s2.mu.Unlock()
+		_, ok := s2.buckets["stale"]
+		return ok
+	}() {
+		t.Error("bucket idle past idleTTL should have been evicted")
+	}
+
+	fs := rl.shardFor("fresh")
+	fs.mu.Lock()
+	_, ok := fs.buckets["fresh"]
+	fs.mu.Unlock()
+	if !ok {
+		t.Error("recently used bucket should not have been evicted")
+	}
+}
@@ -0,0 +1,39 @@
+package middleware
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/Manav7603/Logger/logger"
+)
+
+// Recoverer is a middleware that recovers from panics in the handler chain,
+// logs a single CRITICAL structured record with the panic value, the
+// goroutine stack, and request details, and writes a JSON 500 response
+// instead of letting the panic tear down the server.
+func Recoverer(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		defer func() {
+			if rec := recover(); rec != nil {
+				// skip=4: Callers, CaptureStack, this deferred func, and
+				// runtime.gopanic, so the first frame logged is the function
+				// that actually panicked.
+				FromContext(r.Context()).Critical("panic recovered",
+					logger.Any("panic", fmt.Sprintf("%v", rec)),
+					logger.Any("stack", CaptureStack(4)),
+					logger.String("method", r.Method),
+					logger.String("path", r.URL.Path),
+					logger.String("request_id", requestID(r)),
+				)
+
+				w.Header().Set("Content-Type", "application/json")
+				w.WriteHeader(http.StatusInternalServerError)
+				json.NewEncoder(w).Encode(map[string]string{
+					"error": "internal server error",
+				})
+			}
+		}()
+		next.ServeHTTP(w, r)
+	})
+}
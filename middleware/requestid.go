@@ -0,0 +1,13 @@
+package middleware
+
+import "net/http"
+
+// requestID returns the request ID associated with r: the one Trace stashed
+// in the request context if it ran, otherwise the X-Request-Id header, so
+// Recoverer still has something to log even without Trace in the chain.
+func requestID(r *http.Request) string {
+	if id := RequestIDFromContext(r.Context()); id != "" {
+		return id
+	}
+	return r.Header.Get("X-Request-Id")
+}
@@ -0,0 +1,48 @@
+// Package middleware provides a small chi-style middleware chain on top of
+// the stdlib *http.ServeMux, plus a handful of composable middlewares
+// (Recoverer, request tracing, rate limiting) used by the error-demo app.
+package middleware
+
+import "net/http"
+
+// Middleware wraps an http.Handler to produce another one.
+type Middleware func(http.Handler) http.Handler
+
+// Router is a thin wrapper around *http.ServeMux that applies a chain of
+// Middleware to every request before it reaches the mux.
+type Router struct {
+	mux   *http.ServeMux
+	chain []Middleware
+}
+
+// NewRouter returns an empty Router.
+func NewRouter() *Router {
+	return &Router{mux: http.NewServeMux()}
+}
+
+// Use appends middlewares to the chain applied to every request. The chain is
+// built around the whole mux at request time, so it also covers routes
+// registered with Handle/HandleFunc before Use was called.
+func (rt *Router) Use(mw ...Middleware) {
+	rt.chain = append(rt.chain, mw...)
+}
+
+// Handle registers h for pattern on the underlying mux.
+func (rt *Router) Handle(pattern string, h http.Handler) {
+	rt.mux.Handle(pattern, h)
+}
+
+// HandleFunc registers h for pattern on the underlying mux.
+func (rt *Router) HandleFunc(pattern string, h http.HandlerFunc) {
+	rt.mux.Handle(pattern, h)
+}
+
+// ServeHTTP implements http.Handler, running the registered middleware chain
+// around the underlying mux.
+func (rt *Router) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	var h http.Handler = rt.mux
+	for i := len(rt.chain) - 1; i >= 0; i-- {
+		h = rt.chain[i](h)
+	}
+	h.ServeHTTP(w, r)
+}
@@ -0,0 +1,28 @@
+package middleware
+
+import (
+	"fmt"
+	"runtime"
+)
+
+// CaptureStack walks the call stack starting skip frames up from the caller
+// and renders each frame as "file:line function". It's used by Recoverer and
+// by the endpoint package to attach a stack trace to error log entries.
+func CaptureStack(skip int) []string {
+	const maxFrames = 32
+	pcs := make([]uintptr, maxFrames)
+	n := runtime.Callers(skip, pcs)
+	if n == 0 {
+		return nil
+	}
+	frames := runtime.CallersFrames(pcs[:n])
+	out := make([]string, 0, n)
+	for {
+		frame, more := frames.Next()
+		out = append(out, fmt.Sprintf("%s:%d %s", frame.File, frame.Line, frame.Function))
+		if !more {
+			break
+		}
+	}
+	return out
+}
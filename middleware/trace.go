@@ -0,0 +1,117 @@
+package middleware
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/Manav7603/Logger/logger"
+)
+
+type ctxKey int
+
+const (
+	loggerCtxKey ctxKey = iota
+	requestIDCtxKey
+)
+
+// Trace is a middleware that correlates a request with Cloud Logging traces.
+// It parses X-Cloud-Trace-Context (falling back to the W3C traceparent
+// header), generates a request ID when none is supplied, and stashes a
+// request-scoped Logger in the request context so every log emitted while
+// handling the request carries the same logging.googleapis.com/trace,
+// spanId and trace_sampled fields and is grouped together in the Cloud
+// Logging UI.
+func Trace(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		traceID, spanID, sampled := traceContextFromRequest(r)
+
+		reqID := r.Header.Get("X-Request-Id")
+		if reqID == "" {
+			reqID = newRequestID()
+		}
+		w.Header().Set("X-Request-Id", reqID)
+
+		fields := []logger.Field{logger.String("request_id", reqID)}
+		if traceID != "" {
+			fields = append(fields,
+				logger.String("logging.googleapis.com/trace", fmt.Sprintf("projects/%s/traces/%s", projectID(), traceID)),
+				logger.Bool("logging.googleapis.com/trace_sampled", sampled),
+			)
+			if spanID != "" {
+				fields = append(fields, logger.String("logging.googleapis.com/spanId", spanID))
+			}
+		}
+
+		ctx := context.WithValue(r.Context(), loggerCtxKey, logger.WithFields(logger.LOGHANDLER, fields...))
+		ctx = context.WithValue(ctx, requestIDCtxKey, reqID)
+		next.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
+
+// FromContext returns the request-scoped Logger stashed by Trace, or the
+// package-level logger.LOGHANDLER if Trace hasn't run (e.g. in tests).
+func FromContext(ctx context.Context) logger.Logger {
+	if l, ok := ctx.Value(loggerCtxKey).(logger.Logger); ok {
+		return l
+	}
+	return logger.LOGHANDLER
+}
+
+// RequestIDFromContext returns the request ID stashed by Trace, or "" if
+// Trace hasn't run.
+func RequestIDFromContext(ctx context.Context) string {
+	id, _ := ctx.Value(requestIDCtxKey).(string)
+	return id
+}
+
+// traceContextFromRequest extracts the trace ID, span ID, and sampled flag
+// from X-Cloud-Trace-Context, falling back to the W3C traceparent header.
+func traceContextFromRequest(r *http.Request) (traceID, spanID string, sampled bool) {
+	if h := r.Header.Get("X-Cloud-Trace-Context"); h != "" {
+		if id, span, smp, ok := parseCloudTraceContext(h); ok {
+			return id, span, smp
+		}
+	}
+	if h := r.Header.Get("traceparent"); h != "" {
+		if id, span, smp, ok := parseTraceparent(h); ok {
+			return id, span, smp
+		}
+	}
+	return "", "", false
+}
+
+// parseCloudTraceContext parses the "TRACE_ID/SPAN_ID;o=OPTIONS" format
+// described at https://cloud.google.com/trace/docs/setup#force-trace.
+func parseCloudTraceContext(h string) (traceID, spanID string, sampled bool, ok bool) {
+	if h == "" {
+		return "", "", false, false
+	}
+	slash := strings.IndexByte(h, '/')
+	if slash < 0 {
+		return h, "", false, true
+	}
+	traceID = h[:slash]
+	rest := h[slash+1:]
+	spanID = rest
+	if semi := strings.IndexByte(rest, ';'); semi >= 0 {
+		spanID = rest[:semi]
+		sampled = strings.Contains(rest[semi:], "o=1")
+	}
+	return traceID, spanID, sampled, traceID != ""
+}
+
+// parseTraceparent parses the W3C "00-TRACE_ID-SPAN_ID-FLAGS" format.
+func parseTraceparent(h string) (traceID, spanID string, sampled bool, ok bool) {
+	parts := strings.Split(h, "-")
+	if len(parts) != 4 {
+		return "", "", false, false
+	}
+	traceID, spanID = parts[1], parts[2]
+	if flags, err := strconv.ParseUint(parts[3], 16, 8); err == nil {
+		sampled = flags&1 == 1
+	}
+	return traceID, spanID, sampled, traceID != ""
+}
@@ -0,0 +1,123 @@
+package middleware
+
+import "testing"
+
+func TestParseCloudTraceContext(t *testing.T) {
+	cases := []struct {
+		name        string
+		header      string
+		wantTrace   string
+		wantSpan    string
+		wantSampled bool
+		wantOK      bool
+	}{
+		{
+			name:        "trace, span and sampled",
+			header:      "105445aa7843bc8bf206b12000100000/1;o=1",
+			wantTrace:   "105445aa7843bc8bf206b12000100000",
+			wantSpan:    "1",
+			wantSampled: true,
+			wantOK:      true,
+		},
+		{
+			name:        "not sampled",
+			header:      "105445aa7843bc8bf206b12000100000/1;o=0",
+			wantTrace:   "105445aa7843bc8bf206b12000100000",
+			wantSpan:    "1",
+			wantSampled: false,
+			wantOK:      true,
+		},
+		{
+			name:      "trace only, no span",
+			header:    "105445aa7843bc8bf206b12000100000",
+			wantTrace: "105445aa7843bc8bf206b12000100000",
+			wantSpan:  "",
+			wantOK:    true,
+		},
+		{
+			name:   "empty header",
+			header: "",
+			wantOK: false,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			traceID, spanID, sampled, ok := parseCloudTraceContext(c.header)
+			if ok != c.wantOK {
+				t.Fatalf("ok = %v, want %v", ok, c.wantOK)
+			}
+			if !ok {
+				return
+			}
+			if traceID != c.wantTrace {
+				t.Errorf("traceID = %q, want %q", traceID, c.wantTrace)
+			}
+			if spanID != c.wantSpan {
+				t.Errorf("spanID = %q, want %q", spanID, c.wantSpan)
+			}
+			if sampled != c.wantSampled {
+				t.Errorf("sampled = %v, want %v", sampled, c.wantSampled)
+			}
+		})
+	}
+}
+
+func TestParseTraceparent(t *testing.T) {
+	cases := []struct {
+		name        string
+		header      string
+		wantTrace   string
+		wantSpan    string
+		wantSampled bool
+		wantOK      bool
+	}{
+		{
+			name:        "sampled",
+			header:      "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01",
+			wantTrace:   "4bf92f3577b34da6a3ce929d0e0e4736",
+			wantSpan:    "00f067aa0ba902b7",
+			wantSampled: true,
+			wantOK:      true,
+		},
+		{
+			name:        "not sampled",
+			header:      "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-00",
+			wantTrace:   "4bf92f3577b34da6a3ce929d0e0e4736",
+			wantSpan:    "00f067aa0ba902b7",
+			wantSampled: false,
+			wantOK:      true,
+		},
+		{
+			name:   "wrong number of fields",
+			header: "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7",
+			wantOK: false,
+		},
+		{
+			name:   "empty header",
+			header: "",
+			wantOK: false,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			traceID, spanID, sampled, ok := parseTraceparent(c.header)
+			if ok != c.wantOK {
+				t.Fatalf("ok = %v, want %v", ok, c.wantOK)
+			}
+			if !ok {
+				return
+			}
+			if traceID != c.wantTrace {
+				t.Errorf("traceID = %q, want %q", traceID, c.wantTrace)
+			}
+			if spanID != c.wantSpan {
+				t.Errorf("spanID = %q, want %q", spanID, c.wantSpan)
+			}
+			if sampled != c.wantSampled {
+				t.Errorf("sampled = %v, want %v", sampled, c.wantSampled)
+			}
+		})
+	}
+}
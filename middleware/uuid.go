@@ -0,0 +1,18 @@
+package middleware
+
+import (
+	"crypto/rand"
+	"fmt"
+)
+
+// newRequestID returns a random UUIDv4 used as a request ID when the caller
+// doesn't supply one via X-Request-Id.
+func newRequestID() string {
+	var b [16]byte
+	if _, err := rand.Read(b[:]); err != nil {
+		return fmt.Sprintf("%x", b[:])
+	}
+	b[6] = (b[6] & 0x0f) | 0x40
+	b[8] = (b[8] & 0x3f) | 0x80
+	return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:16])
+}